@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// controlSubscription is one live metric subscription created over a
+// ControlStream. Its sampling goroutine runs under a context rooted in the
+// bidi stream's context, so cancelling the stream tears down every
+// subscription it owns.
+type controlSubscription struct {
+	id     string
+	names  []string
+	labels map[string]string
+
+	intervalMs atomic.Int64
+	paused     atomic.Bool
+	snapshot   chan struct{}
+
+	cancel context.CancelFunc
+}
+
+func (s *monitorServer) ControlStream(stream Monitor_ControlStreamServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var (
+		mu   sync.Mutex // guards subs and sendMu-protected sends below
+		subs = make(map[string]*controlSubscription)
+		wg   sync.WaitGroup
+	)
+	// Send is not safe for concurrent use on a single stream, but every
+	// subscription goroutine shares this one, so all sends go through sendMu.
+	var sendMu sync.Mutex
+	send := func(m *MetricData) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(m)
+	}
+
+	defer func() {
+		mu.Lock()
+		for _, sub := range subs {
+			sub.cancel()
+		}
+		mu.Unlock()
+		wg.Wait()
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch action := msg.GetAction().(type) {
+		case *ControlMessage_Subscribe:
+			req := action.Subscribe
+			mu.Lock()
+			if existing, ok := subs[req.GetSubscriptionId()]; ok {
+				existing.cancel()
+				delete(subs, req.GetSubscriptionId())
+			}
+			subCtx, subCancel := context.WithCancel(ctx)
+			sub := &controlSubscription{
+				id:       req.GetSubscriptionId(),
+				names:    req.GetMetricNames(),
+				labels:   req.GetLabels(),
+				snapshot: make(chan struct{}, 1),
+				cancel:   subCancel,
+			}
+			interval := req.GetIntervalMs()
+			if interval == 0 {
+				interval = 1000
+			}
+			sub.intervalMs.Store(interval)
+			subs[sub.id] = sub
+			mu.Unlock()
+
+			wg.Add(1)
+			s.status.StreamStarted()
+			go func() {
+				defer wg.Done()
+				defer s.status.StreamEnded()
+				s.runSubscription(subCtx, sub, send)
+			}()
+
+		case *ControlMessage_Unsubscribe:
+			mu.Lock()
+			if sub, ok := subs[action.Unsubscribe.GetSubscriptionId()]; ok {
+				sub.cancel()
+				delete(subs, sub.id)
+			}
+			mu.Unlock()
+
+		case *ControlMessage_SetInterval:
+			mu.Lock()
+			sub, ok := subs[action.SetInterval.GetSubscriptionId()]
+			mu.Unlock()
+			if ok && action.SetInterval.GetIntervalMs() > 0 {
+				sub.intervalMs.Store(action.SetInterval.GetIntervalMs())
+			}
+
+		case *ControlMessage_Pause:
+			mu.Lock()
+			sub, ok := subs[action.Pause.GetSubscriptionId()]
+			mu.Unlock()
+			if ok {
+				sub.paused.Store(true)
+			}
+
+		case *ControlMessage_Resume:
+			mu.Lock()
+			sub, ok := subs[action.Resume.GetSubscriptionId()]
+			mu.Unlock()
+			if ok {
+				sub.paused.Store(false)
+			}
+
+		case *ControlMessage_Snapshot:
+			mu.Lock()
+			sub, ok := subs[action.Snapshot.GetSubscriptionId()]
+			mu.Unlock()
+			if ok {
+				select {
+				case sub.snapshot <- struct{}{}:
+				default:
+				}
+			}
+
+		default:
+			s.logger.Warn("ControlStream: received message with no action set")
+		}
+	}
+}
+
+// runSubscription drives one subscription's sampling loop until ctx is
+// cancelled (by Unsubscribe, by the client closing the stream, or by the
+// RPC ending). It fires on its own interval, on an immediate Snapshot
+// request, or not at all while paused.
+func (s *monitorServer) runSubscription(ctx context.Context, sub *controlSubscription, send func(*MetricData) error) {
+	for {
+		interval := time.Duration(sub.intervalMs.Load()) * time.Millisecond
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-sub.snapshot:
+			s.emitSubscription(ctx, sub, send)
+		case <-time.After(interval):
+			if sub.paused.Load() {
+				continue
+			}
+			s.emitSubscription(ctx, sub, send)
+		}
+	}
+}
+
+// emitSubscription samples every metric in sub's filter (or every
+// registered metric if unset) and sends one MetricData per metric, each
+// tagged with sub.id.
+func (s *monitorServer) emitSubscription(ctx context.Context, sub *controlSubscription, send func(*MetricData) error) {
+	names := sub.names
+	if len(names) == 0 {
+		names = s.registry.Names()
+	}
+
+	for _, m := range s.registry.CollectAll(ctx, names) {
+		if m.Err != nil {
+			if errors.Is(m.Err, errUnknownMetric) {
+				s.logger.Warn("ControlStream: unknown metric requested", zap.String("subscription_id", sub.id), zap.String("metric", m.Name))
+			} else {
+				s.logger.Warn("ControlStream: error collecting metric", zap.String("subscription_id", sub.id), zap.String("metric", m.Name), zap.Error(m.Err))
+			}
+			continue
+		}
+
+		metric := &MetricData{
+			Name:           m.Name,
+			Value:          m.Sample.Value,
+			Timestamp:      time.Now().Unix(),
+			Labels:         sub.labels,
+			Min:            m.Sample.Min,
+			Max:            m.Sample.Max,
+			Avg:            m.Sample.Avg,
+			SubscriptionId: sub.id,
+		}
+		if err := send(metric); err != nil {
+			s.logger.Warn("ControlStream: error sending metric", zap.String("subscription_id", sub.id), zap.Error(err))
+			return
+		}
+		s.status.RecordSample(metric)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// faultInjector optionally delays or fails RPCs before they reach the real
+// handler, so the stress subcommand (or any other client) can reproduce the
+// latency spikes and error bursts that client retry/backoff logic needs to
+// be validated against.
+type faultInjector struct {
+	latency   time.Duration
+	errorRate float64
+}
+
+// newFaultInjector builds a faultInjector from the --inject-latency and
+// --inject-error-rate flag values. errorRate is the probability, in [0,1],
+// that a call fails with a synthetic error instead of reaching the handler.
+func newFaultInjector(latency time.Duration, errorRate float64) *faultInjector {
+	return &faultInjector{latency: latency, errorRate: errorRate}
+}
+
+func (f *faultInjector) inject() error {
+	if f.latency > 0 {
+		time.Sleep(f.latency)
+	}
+	if f.errorRate > 0 && rand.Float64() < f.errorRate {
+		return status.Error(codes.Unavailable, "injected failure")
+	}
+	return nil
+}
+
+// UnaryServerInterceptor applies the injected latency/error to unary RPCs.
+func (f *faultInjector) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := f.inject(); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor applies the injected latency/error to streaming
+// RPCs, before the stream handler starts sending or receiving anything.
+func (f *faultInjector) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := f.inject(); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
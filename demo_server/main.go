@@ -2,27 +2,52 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
-	"math/rand"
 	"net"
+	"net/http"
+	"os"
+	"sort"
+	"sync/atomic"
 	"time"
 
+	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
-const (
-	port = ":50051"
+var (
+	grpcPort = flag.String("grpc-port", ":50051", "address the gRPC server listens on")
+	httpPort = flag.String("http-port", ":8080", "address the /metrics, /healthz, /readyz and /status HTTP server listens on")
+
+	tlsCert  = flag.String("tls-cert", "", "path to a PEM-encoded TLS server certificate; enables TLS when set along with --tls-key")
+	tlsKey   = flag.String("tls-key", "", "path to the PEM-encoded TLS server private key")
+	clientCA = flag.String("client-ca", "", "path to a PEM-encoded CA bundle; enables mTLS (requires and verifies client certs) when set")
+
+	allowedClientNames = flag.String("allowed-client-names", "", "comma-separated list of client cert CN/SAN values allowed to call the server; only enforced when --client-ca is set")
+
+	injectLatency   = flag.Duration("inject-latency", 0, "artificial delay injected before every RPC handler call, for exercising client retry/backoff logic")
+	injectErrorRate = flag.Float64("inject-error-rate", 0, "probability (0-1) that an RPC fails with a synthetic Unavailable error instead of being handled, for exercising client retry/backoff logic")
 )
 
 // HelloWorld service implementation
 type greeterServer struct {
 	UnimplementedGreeterServer
+
+	stats  *RequestStats
+	logger *zap.Logger
 }
 
 func (s *greeterServer) SayHello(ctx context.Context, req *HelloRequest) (*HelloReply, error) {
-	log.Printf("Received SayHello request: name=%s", req.GetName())
+	s.stats.Inc()
+	s.logger.Info("received SayHello request", zap.String("name", req.GetName()))
 	return &HelloReply{
 		Message: fmt.Sprintf("Hello, %s! Welcome to godot_grpc demo server.", req.GetName()),
 	}, nil
@@ -31,9 +56,23 @@ func (s *greeterServer) SayHello(ctx context.Context, req *HelloRequest) (*Hello
 // Metrics service implementation
 type monitorServer struct {
 	UnimplementedMonitorServer
+
+	stats    *RequestStats
+	registry *CollectorRegistry
+	status   *StatusTracker
+	logger   *zap.Logger
+}
+
+// NewMonitorServer builds a monitorServer backed by registry.
+func NewMonitorServer(stats *RequestStats, registry *CollectorRegistry, status *StatusTracker, logger *zap.Logger) *monitorServer {
+	return &monitorServer{stats: stats, registry: registry, status: status, logger: logger}
 }
 
 func (s *monitorServer) StreamMetrics(req *MetricsRequest, stream Monitor_StreamMetricsServer) error {
+	s.stats.Inc()
+	s.status.StreamStarted()
+	defer s.status.StreamEnded()
+
 	interval := time.Duration(req.GetIntervalMs()) * time.Millisecond
 	if interval == 0 {
 		interval = 1000 * time.Millisecond
@@ -41,62 +80,177 @@ func (s *monitorServer) StreamMetrics(req *MetricsRequest, stream Monitor_Stream
 
 	count := int(req.GetCount())
 	if count == 0 {
-		count = 10 // Default to 10 metrics
+		count = 10 // Default to 10 ticks
 	}
 
-	log.Printf("Starting metrics stream: interval=%v, count=%d", interval, count)
+	names := req.GetMetricNames()
+	if len(names) == 0 {
+		names = s.registry.Names()
+	}
+	sort.Strings(names)
 
-	metricNames := []string{"cpu_usage", "memory_usage", "disk_io", "network_throughput", "request_count"}
+	labels := req.GetLabels()
 
+	s.logger.Info("starting metrics stream", zap.Duration("interval", interval), zap.Int("count", count), zap.Strings("metrics", names))
+
+	ctx := stream.Context()
 	for i := 0; i < count; i++ {
 		select {
-		case <-stream.Context().Done():
-			log.Println("Client cancelled metrics stream")
-			return stream.Context().Err()
+		case <-ctx.Done():
+			s.logger.Info("client cancelled metrics stream")
+			return ctx.Err()
 		default:
-			// Generate random metric
+		}
+
+		tickStart := time.Now()
+
+		for _, m := range s.registry.CollectAll(ctx, names) {
+			if m.Err != nil {
+				if errors.Is(m.Err, errUnknownMetric) {
+					s.logger.Warn("unknown metric requested", zap.String("metric", m.Name))
+				} else {
+					s.logger.Warn("error collecting metric", zap.String("metric", m.Name), zap.Error(m.Err))
+				}
+				continue
+			}
+
 			metric := &MetricData{
-				Name:      metricNames[rand.Intn(len(metricNames))],
-				Value:     rand.Float64() * 100,
+				Name:      m.Name,
+				Value:     m.Sample.Value,
 				Timestamp: time.Now().Unix(),
+				Labels:    labels,
+				Min:       m.Sample.Min,
+				Max:       m.Sample.Max,
+				Avg:       m.Sample.Avg,
 			}
 
-			log.Printf("Sending metric #%d: %s = %.2f", i+1, metric.Name, metric.Value)
-
 			if err := stream.Send(metric); err != nil {
-				log.Printf("Error sending metric: %v", err)
+				s.logger.Warn("error sending metric", zap.String("metric", m.Name), zap.Error(err))
 				return err
 			}
+			s.status.RecordSample(metric)
+		}
 
-			time.Sleep(interval)
+		if remaining := interval - time.Since(tickStart); remaining > 0 {
+			time.Sleep(remaining)
 		}
 	}
 
-	log.Println("Metrics stream completed")
+	s.logger.Info("metrics stream completed")
 	return nil
 }
 
 func main() {
-	rand.Seed(time.Now().UnixNano())
+	if len(os.Args) > 1 && os.Args[1] == "stress" {
+		runStress(os.Args[2:])
+		return
+	}
+
+	flag.Parse()
+
+	ctx := context.Background()
 
-	lis, err := net.Listen("tcp", port)
+	logger, err := newZapLogger()
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		log.Fatalf("Failed to build logger: %v", err)
 	}
+	defer logger.Sync()
 
-	s := grpc.NewServer()
+	reg := prometheus.NewRegistry()
+	srvMetrics := newGRPCServerMetrics(reg)
+
+	// ctxtags first so every later interceptor can attach fields to the
+	// call; grpc_zap logs one line per RPC using whatever fields ended up
+	// on those tags; requestMetaInterceptor adds peer address and, for
+	// streams, message counts. grpc_recovery is appended last, once the
+	// optional peer allowlist interceptor is known, so it stays innermost
+	// and wraps the actual handler (and the allowlist check) in a single
+	// panic boundary.
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpc_ctxtags.UnaryServerInterceptor(),
+		srvMetrics.UnaryServerInterceptor(),
+		grpc_zap.UnaryServerInterceptor(logger),
+		requestMetaUnaryInterceptor,
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		grpc_ctxtags.StreamServerInterceptor(),
+		srvMetrics.StreamServerInterceptor(),
+		grpc_zap.StreamServerInterceptor(logger),
+		requestMetaStreamInterceptor,
+	}
+
+	var serverOpts []grpc.ServerOption
+	if *tlsCert != "" || *tlsKey != "" {
+		tlsConfig, err := buildServerTLSConfig(ctx, *tlsCert, *tlsKey, *clientCA)
+		if err != nil {
+			log.Fatalf("Failed to configure TLS: %v", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+
+		if *clientCA != "" {
+			if allowlist, enabled := newPeerIdentityAllowlist(*allowedClientNames); enabled {
+				unaryInterceptors = append(unaryInterceptors, allowlist.UnaryServerInterceptor())
+				streamInterceptors = append(streamInterceptors, allowlist.StreamServerInterceptor())
+			}
+		}
+	} else if *clientCA != "" {
+		log.Fatalf("--client-ca requires --tls-cert and --tls-key")
+	}
+
+	if *injectLatency > 0 || *injectErrorRate > 0 {
+		injector := newFaultInjector(*injectLatency, *injectErrorRate)
+		unaryInterceptors = append(unaryInterceptors, injector.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, injector.StreamServerInterceptor())
+	}
+
+	unaryInterceptors = append(unaryInterceptors, grpc_recovery.UnaryServerInterceptor(grpc_recovery.WithRecoveryHandlerContext(panicRecoveryHandler)))
+	streamInterceptors = append(streamInterceptors, grpc_recovery.StreamServerInterceptor(grpc_recovery.WithRecoveryHandlerContext(panicRecoveryHandler)))
+
+	serverOpts = append(serverOpts,
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+	s := grpc.NewServer(serverOpts...)
+
+	stats := &RequestStats{}
+	status := NewStatusTracker()
+	registry := NewCollectorRegistry(NewGopsutilCollectors(stats)...)
 
 	// Register services
-	RegisterGreeterServer(s, &greeterServer{})
-	RegisterMonitorServer(s, &monitorServer{})
+	RegisterGreeterServer(s, &greeterServer{stats: stats, logger: logger})
+	RegisterMonitorServer(s, NewMonitorServer(stats, registry, status, logger))
 
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
+	srvMetrics.InitializeMetrics(s)
+
+	var ready atomic.Bool
+	httpSrv := newObservabilityServer(*httpPort, reg, status, &ready)
+	go func() {
+		log.Printf("Observability server listening on %s (/metrics, /healthz, /readyz, /status)", *httpPort)
+		if err := httpSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Observability server failed: %v", err)
+		}
+	}()
+
+	lis, err := net.Listen("tcp", *grpcPort)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+	ready.Store(true)
 
-	log.Printf("godot_grpc demo server listening on %s", port)
+	scheme := "insecure"
+	if *tlsCert != "" {
+		scheme = "tls"
+		if *clientCA != "" {
+			scheme = "mtls"
+		}
+	}
+	log.Printf("godot_grpc demo server listening on %s (%s)", *grpcPort, scheme)
 	log.Println("Available services:")
 	log.Println("  - helloworld.Greeter/SayHello (unary)")
 	log.Println("  - metrics.Monitor/StreamMetrics (server-streaming)")
+	log.Println("  - metrics.Monitor/ControlStream (bidirectional streaming)")
 
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// errUnknownMetric is wrapped into CollectedMetric.Err by CollectAll when a
+// requested name has no registered collector.
+var errUnknownMetric = errors.New("unknown metric")
+
+// subSamples is the number of readings a collector takes within its
+// sampling window so that Sample.Min/Max/Avg reflect real variation
+// instead of a single point-in-time value.
+const subSamples = 4
+
+// collectorWindow bounds how long a single MetricCollector.Collect call may
+// take to gather its sub-samples. It is independent of the tick interval
+// requested by the client: StreamMetrics paces ticks itself and simply
+// sleeps out whatever of the interval collectorWindow didn't use.
+const collectorWindow = 200 * time.Millisecond
+
+// Sample is one aggregated reading produced by a MetricCollector.
+type Sample struct {
+	// Value is the representative reading for this sample, equal to Avg.
+	Value  float64
+	Min    float64
+	Max    float64
+	Avg    float64
+	Labels map[string]string
+}
+
+// MetricCollector produces a single named metric. Implementations are
+// registered with a CollectorRegistry and invoked once per tick of
+// monitorServer.StreamMetrics.
+type MetricCollector interface {
+	// Name is the metric name reported on MetricData, e.g. "cpu_usage".
+	Name() string
+	// Collect samples the metric repeatedly over collectorWindow and
+	// returns an aggregated Sample. It must respect ctx cancellation.
+	Collect(ctx context.Context) (Sample, error)
+}
+
+// CollectorRegistry holds the set of collectors a monitorServer can stream.
+type CollectorRegistry struct {
+	collectors map[string]MetricCollector
+}
+
+// NewCollectorRegistry builds a registry from the given collectors, keyed
+// by their Name().
+func NewCollectorRegistry(collectors ...MetricCollector) *CollectorRegistry {
+	r := &CollectorRegistry{collectors: make(map[string]MetricCollector, len(collectors))}
+	for _, c := range collectors {
+		r.collectors[c.Name()] = c
+	}
+	return r
+}
+
+// Names returns every registered metric name, in registration order being
+// unspecified (map iteration order).
+func (r *CollectorRegistry) Names() []string {
+	names := make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Get looks up a collector by name.
+func (r *CollectorRegistry) Get(name string) (MetricCollector, bool) {
+	c, ok := r.collectors[name]
+	return c, ok
+}
+
+// CollectedMetric pairs a requested metric name with its sampled Sample, or
+// with Err set if the name is unregistered or collection failed.
+type CollectedMetric struct {
+	Name   string
+	Sample Sample
+	Err    error
+}
+
+// CollectAll samples every name in names concurrently, so the total cost of
+// one tick is bounded by the slowest single MetricCollector.Collect call
+// (collectorWindow) instead of growing linearly with len(names). Results are
+// returned in the same order as names.
+func (r *CollectorRegistry) CollectAll(ctx context.Context, names []string) []CollectedMetric {
+	results := make([]CollectedMetric, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		collector, ok := r.Get(name)
+		if !ok {
+			results[i] = CollectedMetric{Name: name, Err: fmt.Errorf("%w: %s", errUnknownMetric, name)}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, name string, collector MetricCollector) {
+			defer wg.Done()
+			sample, err := collector.Collect(ctx)
+			results[i] = CollectedMetric{Name: name, Sample: sample, Err: err}
+		}(i, name, collector)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// sampleOverInterval calls read subSamples times, spaced evenly across
+// collectorWindow, and aggregates the results into min/max/avg. It stops
+// early if ctx is cancelled.
+func sampleOverInterval(ctx context.Context, read func() (float64, error)) (Sample, error) {
+	gap := collectorWindow / subSamples
+	var (
+		min, max, sum float64
+		n             int
+		lastErr       error
+	)
+	for i := 0; i < subSamples; i++ {
+		v, err := read()
+		if err != nil {
+			lastErr = err
+		} else {
+			if n == 0 || v < min {
+				min = v
+			}
+			if n == 0 || v > max {
+				max = v
+			}
+			sum += v
+			n++
+		}
+		if i < subSamples-1 {
+			select {
+			case <-ctx.Done():
+				i = subSamples
+			case <-time.After(gap):
+			}
+		}
+	}
+	if n == 0 {
+		return Sample{}, lastErr
+	}
+	avg := sum / float64(n)
+	return Sample{Value: avg, Min: min, Max: max, Avg: avg}, nil
+}
+
+// cpuCollector reports overall CPU utilization as a percentage.
+type cpuCollector struct{}
+
+func (cpuCollector) Name() string { return "cpu_usage" }
+
+func (cpuCollector) Collect(ctx context.Context) (Sample, error) {
+	return sampleOverInterval(ctx, func() (float64, error) {
+		percents, err := cpu.PercentWithContext(ctx, 0, false)
+		if err != nil {
+			return 0, err
+		}
+		if len(percents) == 0 {
+			return 0, fmt.Errorf("cpu: no readings returned")
+		}
+		return percents[0], nil
+	})
+}
+
+// memoryCollector reports used virtual memory as a percentage.
+type memoryCollector struct{}
+
+func (memoryCollector) Name() string { return "memory_usage" }
+
+func (memoryCollector) Collect(ctx context.Context) (Sample, error) {
+	return sampleOverInterval(ctx, func() (float64, error) {
+		vm, err := mem.VirtualMemoryWithContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return vm.UsedPercent, nil
+	})
+}
+
+// diskIOCollector reports aggregate disk read+write throughput in bytes/sec,
+// computed from the delta between consecutive IOCounters readings.
+type diskIOCollector struct{}
+
+func (diskIOCollector) Name() string { return "disk_io" }
+
+func (diskIOCollector) Collect(ctx context.Context) (Sample, error) {
+	gap := collectorWindow / subSamples
+	prev, prevAt, err := readDiskBytes(ctx)
+	if err != nil {
+		return Sample{}, err
+	}
+	var min, max, sum float64
+	n := 0
+	for i := 0; i < subSamples; i++ {
+		select {
+		case <-ctx.Done():
+			i = subSamples
+			continue
+		case <-time.After(gap):
+		}
+		cur, curAt, err := readDiskBytes(ctx)
+		if err != nil {
+			continue
+		}
+		elapsed := curAt.Sub(prevAt).Seconds()
+		if elapsed <= 0 {
+			continue
+		}
+		rate := float64(cur-prev) / elapsed
+		if n == 0 || rate < min {
+			min = rate
+		}
+		if n == 0 || rate > max {
+			max = rate
+		}
+		sum += rate
+		n++
+		prev, prevAt = cur, curAt
+	}
+	if n == 0 {
+		return Sample{}, fmt.Errorf("disk_io: no readings collected")
+	}
+	avg := sum / float64(n)
+	return Sample{Value: avg, Min: min, Max: max, Avg: avg}, nil
+}
+
+func readDiskBytes(ctx context.Context) (uint64, time.Time, error) {
+	counters, err := disk.IOCountersWithContext(ctx)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	var total uint64
+	for _, c := range counters {
+		total += c.ReadBytes + c.WriteBytes
+	}
+	return total, time.Now(), nil
+}
+
+// networkThroughputCollector reports aggregate network send+receive
+// throughput in bytes/sec across all interfaces.
+type networkThroughputCollector struct{}
+
+func (networkThroughputCollector) Name() string { return "network_throughput" }
+
+func (networkThroughputCollector) Collect(ctx context.Context) (Sample, error) {
+	gap := collectorWindow / subSamples
+	prev, prevAt, err := readNetBytes(ctx)
+	if err != nil {
+		return Sample{}, err
+	}
+	var min, max, sum float64
+	n := 0
+	for i := 0; i < subSamples; i++ {
+		select {
+		case <-ctx.Done():
+			i = subSamples
+			continue
+		case <-time.After(gap):
+		}
+		cur, curAt, err := readNetBytes(ctx)
+		if err != nil {
+			continue
+		}
+		elapsed := curAt.Sub(prevAt).Seconds()
+		if elapsed <= 0 {
+			continue
+		}
+		rate := float64(cur-prev) / elapsed
+		if n == 0 || rate < min {
+			min = rate
+		}
+		if n == 0 || rate > max {
+			max = rate
+		}
+		sum += rate
+		n++
+		prev, prevAt = cur, curAt
+	}
+	if n == 0 {
+		return Sample{}, fmt.Errorf("network_throughput: no readings collected")
+	}
+	avg := sum / float64(n)
+	return Sample{Value: avg, Min: min, Max: max, Avg: avg}, nil
+}
+
+func readNetBytes(ctx context.Context) (uint64, time.Time, error) {
+	counters, err := net.IOCountersWithContext(ctx, false)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if len(counters) == 0 {
+		return 0, time.Time{}, fmt.Errorf("network: no interfaces returned")
+	}
+	return counters[0].BytesSent + counters[0].BytesRecv, time.Now(), nil
+}
+
+// goroutineCollector reports the current number of live goroutines, a
+// cheap proxy for server-side concurrency.
+type goroutineCollector struct{}
+
+func (goroutineCollector) Name() string { return "goroutine_count" }
+
+func (goroutineCollector) Collect(ctx context.Context) (Sample, error) {
+	return sampleOverInterval(ctx, func() (float64, error) {
+		return float64(runtime.NumGoroutine()), nil
+	})
+}
+
+// RequestStats tracks RPC request counts shared across services so a
+// requestCountCollector can expose them as a metric.
+type RequestStats struct {
+	count int64
+}
+
+// Inc records one completed RPC.
+func (s *RequestStats) Inc() {
+	atomic.AddInt64(&s.count, 1)
+}
+
+// Load returns the cumulative request count.
+func (s *RequestStats) Load() int64 {
+	return atomic.LoadInt64(&s.count)
+}
+
+// requestCountCollector reports the cumulative number of RPCs handled by
+// this server since startup.
+type requestCountCollector struct {
+	stats *RequestStats
+}
+
+func (requestCountCollector) Name() string { return "request_count" }
+
+func (c requestCountCollector) Collect(ctx context.Context) (Sample, error) {
+	v := float64(c.stats.Load())
+	return Sample{Value: v, Min: v, Max: v, Avg: v}, nil
+}
+
+// NewGopsutilCollectors returns the default set of collectors backed by
+// gopsutil and the server's shared RequestStats.
+func NewGopsutilCollectors(stats *RequestStats) []MetricCollector {
+	return []MetricCollector{
+		cpuCollector{},
+		memoryCollector{},
+		diskIOCollector{},
+		networkThroughputCollector{},
+		goroutineCollector{},
+		requestCountCollector{stats: stats},
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSampleOverInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		reads   []float64
+		readErr error
+		wantErr bool
+		wantMin float64
+		wantMax float64
+		wantAvg float64
+	}{
+		{
+			name:    "aggregates min max avg",
+			reads:   []float64{1, 2, 3, 4},
+			wantMin: 1,
+			wantMax: 4,
+			wantAvg: 2.5,
+		},
+		{
+			name:    "constant readings",
+			reads:   []float64{5, 5, 5, 5},
+			wantMin: 5,
+			wantMax: 5,
+			wantAvg: 5,
+		},
+		{
+			name:    "every read fails",
+			readErr: errors.New("boom"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := 0
+			read := func() (float64, error) {
+				if tt.readErr != nil {
+					return 0, tt.readErr
+				}
+				v := tt.reads[i%len(tt.reads)]
+				i++
+				return v, nil
+			}
+
+			sample, err := sampleOverInterval(context.Background(), read)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got sample %+v", sample)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sample.Min != tt.wantMin {
+				t.Errorf("Min = %v, want %v", sample.Min, tt.wantMin)
+			}
+			if sample.Max != tt.wantMax {
+				t.Errorf("Max = %v, want %v", sample.Max, tt.wantMax)
+			}
+			if sample.Avg != tt.wantAvg {
+				t.Errorf("Avg = %v, want %v", sample.Avg, tt.wantAvg)
+			}
+			if sample.Value != sample.Avg {
+				t.Errorf("Value = %v, want equal to Avg %v", sample.Value, sample.Avg)
+			}
+		})
+	}
+}
+
+func TestSampleOverIntervalRespectsCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	sample, err := sampleOverInterval(ctx, func() (float64, error) { return 1, nil })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sample.Avg != 1 {
+		t.Errorf("Avg = %v, want 1 from the single reading taken before cancellation", sample.Avg)
+	}
+	if elapsed := time.Since(start); elapsed > collectorWindow {
+		t.Errorf("took %v, want well under collectorWindow (%v) once cancelled", elapsed, collectorWindow)
+	}
+}
+
+// fakeCollector is a MetricCollector whose Collect always takes the full
+// collectorWindow, mirroring the real gopsutil-backed collectors.
+type fakeCollector struct {
+	name string
+}
+
+func (c fakeCollector) Name() string { return c.name }
+
+func (c fakeCollector) Collect(ctx context.Context) (Sample, error) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(collectorWindow):
+	}
+	return Sample{Value: 1, Min: 1, Max: 1, Avg: 1}, nil
+}
+
+func TestCollectorRegistryCollectAllRunsConcurrently(t *testing.T) {
+	const metricCount = 6
+	names := make([]string, metricCount)
+	collectors := make([]MetricCollector, metricCount)
+	for i := range names {
+		names[i] = string(rune('a' + i))
+		collectors[i] = fakeCollector{name: names[i]}
+	}
+	registry := NewCollectorRegistry(collectors...)
+
+	start := time.Now()
+	results := registry.CollectAll(context.Background(), names)
+	elapsed := time.Since(start)
+
+	// Sequential collection would take metricCount*collectorWindow; a
+	// passing run must stay well under that, close to a single window.
+	if elapsed >= metricCount*collectorWindow {
+		t.Errorf("CollectAll took %v across %d metrics, want close to a single collectorWindow (%v)", elapsed, metricCount, collectorWindow)
+	}
+
+	if len(results) != metricCount {
+		t.Fatalf("got %d results, want %d", len(results), metricCount)
+	}
+	for i, res := range results {
+		if res.Name != names[i] {
+			t.Errorf("results[%d].Name = %q, want %q (order must match names)", i, res.Name, names[i])
+		}
+		if res.Err != nil {
+			t.Errorf("results[%d].Err = %v, want nil", i, res.Err)
+		}
+	}
+}
+
+func TestCollectorRegistryCollectAllUnknownMetric(t *testing.T) {
+	registry := NewCollectorRegistry(fakeCollector{name: "known"})
+
+	results := registry.CollectAll(context.Background(), []string{"missing"})
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if !errors.Is(results[0].Err, errUnknownMetric) {
+		t.Errorf("Err = %v, want errUnknownMetric", results[0].Err)
+	}
+}
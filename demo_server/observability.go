@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync/atomic"
+
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// newGRPCServerMetrics builds the go-grpc-middleware Prometheus server
+// metrics and registers them with reg, ready to be wired into
+// grpc.NewServer via its UnaryServerInterceptor/StreamServerInterceptor.
+func newGRPCServerMetrics(reg *prometheus.Registry) *grpcprom.ServerMetrics {
+	srvMetrics := grpcprom.NewServerMetrics(
+		grpcprom.WithServerHandlingTimeHistogram(),
+	)
+	reg.MustRegister(srvMetrics)
+	return srvMetrics
+}
+
+// newObservabilityServer builds the HTTP server exposing /metrics,
+// /healthz, /readyz and /status. ready is flipped to true once the gRPC
+// listener is up; /readyz reports 503 until then.
+func newObservabilityServer(addr string, reg *prometheus.Registry, status *StatusTracker, ready *atomic.Bool) *http.Server {
+	mux := http.NewServeMux()
+
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !ready.Load() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(status.Snapshot()); err != nil {
+			log.Printf("Error encoding /status response: %v", err)
+		}
+	})
+
+	return &http.Server{Addr: addr, Handler: mux}
+}
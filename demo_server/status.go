@@ -0,0 +1,100 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// version is the demo server's build version. Override at build time with
+// -ldflags "-X main.version=...".
+var version = "dev"
+
+var startTime = time.Now()
+
+// MetricSnapshot is the last sample recorded for one metric name, used by
+// the /status endpoint.
+type MetricSnapshot struct {
+	Value     float64           `json:"value"`
+	Min       float64           `json:"min"`
+	Max       float64           `json:"max"`
+	Avg       float64           `json:"avg"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Timestamp int64             `json:"timestamp"`
+}
+
+// BuildInfo describes the running binary.
+type BuildInfo struct {
+	Version   string    `json:"version"`
+	GoVersion string    `json:"go_version"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// StatusResponse is the JSON body served at /status.
+type StatusResponse struct {
+	ActiveStreams int64                     `json:"active_streams"`
+	LastSample    map[string]MetricSnapshot `json:"last_sample"`
+	Build         BuildInfo                 `json:"build"`
+}
+
+// StatusTracker accumulates the counters and last-sample cache behind the
+// /status endpoint. It is shared by monitorServer's StreamMetrics and
+// ControlStream handlers.
+type StatusTracker struct {
+	activeStreams atomic.Int64
+
+	mu         sync.RWMutex
+	lastSample map[string]MetricSnapshot
+}
+
+// NewStatusTracker returns an empty StatusTracker.
+func NewStatusTracker() *StatusTracker {
+	return &StatusTracker{lastSample: make(map[string]MetricSnapshot)}
+}
+
+// StreamStarted records that a new metrics stream (StreamMetrics call or
+// ControlStream subscription) began.
+func (t *StatusTracker) StreamStarted() {
+	t.activeStreams.Add(1)
+}
+
+// StreamEnded records that a metrics stream ended.
+func (t *StatusTracker) StreamEnded() {
+	t.activeStreams.Add(-1)
+}
+
+// RecordSample caches m as the most recent sample for its metric name.
+func (t *StatusTracker) RecordSample(m *MetricData) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastSample[m.GetName()] = MetricSnapshot{
+		Value:     m.GetValue(),
+		Min:       m.GetMin(),
+		Max:       m.GetMax(),
+		Avg:       m.GetAvg(),
+		Labels:    m.GetLabels(),
+		Timestamp: m.GetTimestamp(),
+	}
+}
+
+// Snapshot returns the current status for serialization.
+func (t *StatusTracker) Snapshot() StatusResponse {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	lastSample := make(map[string]MetricSnapshot, len(t.lastSample))
+	for name, sample := range t.lastSample {
+		lastSample[name] = sample
+	}
+
+	return StatusResponse{
+		ActiveStreams: t.activeStreams.Load(),
+		LastSample:    lastSample,
+		Build: BuildInfo{
+			Version:   version,
+			GoVersion: runtime.Version(),
+			StartedAt: startTime,
+		},
+	}
+}
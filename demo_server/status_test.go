@@ -0,0 +1,78 @@
+package main
+
+import "testing"
+
+func TestStatusTrackerStreamCounting(t *testing.T) {
+	tracker := NewStatusTracker()
+
+	if got := tracker.Snapshot().ActiveStreams; got != 0 {
+		t.Fatalf("ActiveStreams = %d, want 0 before any stream starts", got)
+	}
+
+	tracker.StreamStarted()
+	tracker.StreamStarted()
+	if got := tracker.Snapshot().ActiveStreams; got != 2 {
+		t.Fatalf("ActiveStreams = %d, want 2 after two StreamStarted calls", got)
+	}
+
+	tracker.StreamEnded()
+	if got := tracker.Snapshot().ActiveStreams; got != 1 {
+		t.Fatalf("ActiveStreams = %d, want 1 after one StreamEnded call", got)
+	}
+}
+
+func TestStatusTrackerRecordSample(t *testing.T) {
+	tracker := NewStatusTracker()
+
+	tracker.RecordSample(&MetricData{
+		Name:      "cpu_usage",
+		Value:     12.5,
+		Min:       10,
+		Max:       15,
+		Avg:       12.5,
+		Labels:    map[string]string{"host": "demo"},
+		Timestamp: 1700000000,
+	})
+
+	snap := tracker.Snapshot()
+	got, ok := snap.LastSample["cpu_usage"]
+	if !ok {
+		t.Fatalf("LastSample missing cpu_usage entry: %+v", snap.LastSample)
+	}
+	want := MetricSnapshot{
+		Value:     12.5,
+		Min:       10,
+		Max:       15,
+		Avg:       12.5,
+		Labels:    map[string]string{"host": "demo"},
+		Timestamp: 1700000000,
+	}
+	if got.Value != want.Value || got.Min != want.Min || got.Max != want.Max || got.Avg != want.Avg || got.Timestamp != want.Timestamp {
+		t.Errorf("LastSample[cpu_usage] = %+v, want %+v", got, want)
+	}
+
+	// RecordSample for a second metric must not clobber the first, and a
+	// later sample for the same metric must overwrite it.
+	tracker.RecordSample(&MetricData{Name: "memory_usage", Value: 42})
+	tracker.RecordSample(&MetricData{Name: "cpu_usage", Value: 99, Avg: 99})
+
+	snap = tracker.Snapshot()
+	if len(snap.LastSample) != 2 {
+		t.Fatalf("LastSample has %d entries, want 2: %+v", len(snap.LastSample), snap.LastSample)
+	}
+	if snap.LastSample["cpu_usage"].Value != 99 {
+		t.Errorf("cpu_usage.Value = %v, want 99 after overwrite", snap.LastSample["cpu_usage"].Value)
+	}
+}
+
+func TestStatusTrackerSnapshotIsolation(t *testing.T) {
+	tracker := NewStatusTracker()
+	tracker.RecordSample(&MetricData{Name: "cpu_usage", Value: 1})
+
+	snap := tracker.Snapshot()
+	snap.LastSample["cpu_usage"] = MetricSnapshot{Value: 1000}
+
+	if got := tracker.Snapshot().LastSample["cpu_usage"].Value; got != 1 {
+		t.Errorf("mutating a returned Snapshot affected the tracker's internal state: got %v, want 1", got)
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// stressSample is one completed RPC attempt recorded by a stress worker.
+type stressSample struct {
+	rpc     string
+	latency time.Duration
+	code    codes.Code
+}
+
+// stressResult accumulates the samples produced by every worker, plus the
+// running total of metric values received over StreamMetrics calls (used
+// for the streaming throughput figure).
+type stressResult struct {
+	mu         sync.Mutex
+	samples    []stressSample
+	streamMsgs int64
+}
+
+func (r *stressResult) record(s stressSample) {
+	r.mu.Lock()
+	r.samples = append(r.samples, s)
+	r.mu.Unlock()
+}
+
+func (r *stressResult) addStreamMsgs(n int64) {
+	atomic.AddInt64(&r.streamMsgs, n)
+}
+
+// runStress implements the `demo_server stress` subcommand: a load-testing
+// client for Greeter/SayHello and Monitor/StreamMetrics, driving --concurrency
+// goroutines at an aggregate --qps, then printing a latency/error summary
+// (and optionally a per-request CSV).
+func runStress(args []string) {
+	fs := flag.NewFlagSet("stress", flag.ExitOnError)
+	target := fs.String("target", "localhost:50051", "address of the gRPC server to load")
+	rpcName := fs.String("rpc", "both", "which RPC to exercise: sayhello, streammetrics, or both")
+	concurrency := fs.Int("concurrency", 10, "number of concurrent worker goroutines")
+	qps := fs.Float64("qps", 0, "target aggregate requests/sec across all workers; 0 means unlimited")
+	duration := fs.Duration("duration", 10*time.Second, "how long to run the stress test")
+	streamCount := fs.Int("stream-count", 5, "number of ticks requested per StreamMetrics call")
+	streamIntervalMs := fs.Int64("stream-interval-ms", 200, "interval_ms requested per StreamMetrics call")
+	csvPath := fs.String("csv", "", "optional path to write a per-request CSV (rpc,latency_ms,code)")
+	fs.Parse(args)
+
+	switch *rpcName {
+	case "sayhello", "streammetrics", "both":
+	default:
+		log.Fatalf("stress: unknown --rpc %q (want sayhello, streammetrics, or both)", *rpcName)
+	}
+
+	conn, err := grpc.NewClient(*target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("stress: failed to dial %s: %v", *target, err)
+	}
+	defer conn.Close()
+
+	greeter := NewGreeterClient(conn)
+	monitor := NewMonitorClient(conn)
+
+	var limiter <-chan time.Time
+	if *qps > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / *qps))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	result := &stressResult{}
+	deadline := time.Now().Add(*duration)
+
+	var wg sync.WaitGroup
+	for i := 0; i < *concurrency; i++ {
+		wg.Add(1)
+		workerID := i
+		go func() {
+			defer wg.Done()
+			for n := 0; time.Now().Before(deadline); n++ {
+				if limiter != nil {
+					<-limiter
+				}
+
+				rpc := *rpcName
+				if rpc == "both" {
+					if (workerID+n)%2 == 0 {
+						rpc = "sayhello"
+					} else {
+						rpc = "streammetrics"
+					}
+				}
+
+				switch rpc {
+				case "sayhello":
+					result.record(callSayHello(greeter))
+				case "streammetrics":
+					result.record(callStreamMetrics(monitor, result, *streamCount, *streamIntervalMs))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	elapsed := *duration
+	printStressSummary(os.Stdout, result, elapsed, *concurrency, *qps)
+
+	if *csvPath != "" {
+		if err := writeStressCSV(*csvPath, result); err != nil {
+			log.Fatalf("stress: failed to write CSV: %v", err)
+		}
+	}
+}
+
+func callSayHello(client GreeterClient) stressSample {
+	start := time.Now()
+	_, err := client.SayHello(context.Background(), &HelloRequest{Name: "stress"})
+	return stressSample{rpc: "sayhello", latency: time.Since(start), code: status.Code(err)}
+}
+
+func callStreamMetrics(client MonitorClient, result *stressResult, count int, intervalMs int64) stressSample {
+	start := time.Now()
+	stream, err := client.StreamMetrics(context.Background(), &MetricsRequest{
+		IntervalMs: intervalMs,
+		Count:      int64(count),
+	})
+	if err != nil {
+		return stressSample{rpc: "streammetrics", latency: time.Since(start), code: status.Code(err)}
+	}
+
+	var msgs int64
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			err = nil
+			break
+		}
+		if err != nil {
+			break
+		}
+		msgs++
+	}
+	result.addStreamMsgs(msgs)
+	return stressSample{rpc: "streammetrics", latency: time.Since(start), code: status.Code(err)}
+}
+
+// percentile returns the nearest-rank percentile (p in [0,1]) of a
+// pre-sorted (ascending) slice of durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+func printStressSummary(w io.Writer, result *stressResult, elapsed time.Duration, concurrency int, qps float64) {
+	result.mu.Lock()
+	samples := append([]stressSample(nil), result.samples...)
+	streamMsgs := result.streamMsgs
+	result.mu.Unlock()
+
+	latencies := make([]time.Duration, len(samples))
+	codeCounts := make(map[codes.Code]int64)
+	for i, s := range samples {
+		latencies[i] = s.latency
+		codeCounts[s.code]++
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	fmt.Fprintf(w, "stress summary: %d requests in %s (concurrency=%d, target qps=%.1f, actual qps=%.1f)\n",
+		len(samples), elapsed, concurrency, qps, float64(len(samples))/elapsed.Seconds())
+	fmt.Fprintf(w, "latency: p50=%s p90=%s p99=%s\n",
+		percentile(latencies, 0.50), percentile(latencies, 0.90), percentile(latencies, 0.99))
+
+	fmt.Fprintln(w, "results by code:")
+	var errs int64
+	for code, n := range codeCounts {
+		fmt.Fprintf(w, "  %-16s %d\n", code, n)
+		if code != codes.OK {
+			errs += n
+		}
+	}
+	if len(samples) > 0 {
+		fmt.Fprintf(w, "error rate: %.2f%%\n", 100*float64(errs)/float64(len(samples)))
+	}
+
+	if streamMsgs > 0 {
+		fmt.Fprintf(w, "streaming throughput: %d metric messages (%.1f msgs/sec)\n",
+			streamMsgs, float64(streamMsgs)/elapsed.Seconds())
+	}
+}
+
+func writeStressCSV(path string, result *stressResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	result.mu.Lock()
+	samples := append([]stressSample(nil), result.samples...)
+	result.mu.Unlock()
+
+	cw := csv.NewWriter(f)
+	if err := cw.Write([]string{"rpc", "latency_ms", "code"}); err != nil {
+		return err
+	}
+	for _, s := range samples {
+		row := []string{
+			s.rpc,
+			strconv.FormatFloat(float64(s.latency)/float64(time.Millisecond), 'f', 3, 64),
+			s.code.String(),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	ms := func(n int) time.Duration { return time.Duration(n) * time.Millisecond }
+
+	sorted := []time.Duration{ms(1), ms(2), ms(3), ms(4), ms(5), ms(6), ms(7), ms(8), ms(9), ms(10)}
+
+	tests := []struct {
+		name string
+		p    float64
+		want time.Duration
+	}{
+		{name: "p0 is the minimum", p: 0, want: ms(1)},
+		{name: "p50", p: 0.5, want: ms(5)},
+		{name: "p90", p: 0.9, want: ms(9)},
+		{name: "p100 is the maximum", p: 1, want: ms(10)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(sorted, tt.p); got != tt.want {
+				t.Errorf("percentile(sorted, %v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentileEmpty(t *testing.T) {
+	if got := percentile(nil, 0.5); got != 0 {
+		t.Errorf("percentile(nil, 0.5) = %v, want 0", got)
+	}
+}
+
+func TestPercentileSingleElement(t *testing.T) {
+	sorted := []time.Duration{42 * time.Millisecond}
+	for _, p := range []float64{0, 0.5, 0.99, 1} {
+		if got := percentile(sorted, p); got != 42*time.Millisecond {
+			t.Errorf("percentile(single, %v) = %v, want 42ms", p, got)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// certReloader holds the currently-loaded server certificate and keeps it
+// current by re-reading certFile/keyFile whenever fsnotify reports either
+// one changed, so rotating certs on disk doesn't require a server restart.
+type certReloader struct {
+	certFile, keyFile string
+	cert              atomic.Pointer[tls.Certificate]
+}
+
+// newCertReloader loads certFile/keyFile once and starts a goroutine that
+// reloads them on change, for as long as ctx is alive.
+func newCertReloader(ctx context.Context, certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("tls: creating fsnotify watcher: %w", err)
+	}
+
+	// Watch the parent directory, not the files themselves: atomic cert
+	// rotation (write a temp file, then rename it over certFile/keyFile, the
+	// pattern used by cert-manager, certbot and acme.sh) replaces the watched
+	// inode, which surfaces as a REMOVE on a file-level watch and silently
+	// kills the watch for good. Watching the directory survives the rename
+	// and keeps seeing every future rotation.
+	certBase, keyBase := filepath.Base(certFile), filepath.Base(keyFile)
+	dirs := map[string]struct{}{filepath.Dir(certFile): {}, filepath.Dir(keyFile): {}}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, fmt.Errorf("tls: watching %s: %w", dir, err)
+		}
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				base := filepath.Base(event.Name)
+				if base != certBase && base != keyBase {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				if err := r.reload(); err != nil {
+					log.Printf("tls: failed to reload certificate after %s: %v", event, err)
+					continue
+				}
+				log.Printf("tls: reloaded certificate after %s", event)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("tls: fsnotify watcher error: %v", err)
+			}
+		}
+	}()
+
+	return r, nil
+}
+
+func (r *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("tls: loading key pair: %w", err)
+	}
+	r.cert.Store(&cert)
+	return nil
+}
+
+// GetCertificate is the tls.Config.GetCertificate callback backed by the
+// most recently loaded certificate.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.cert.Load(), nil
+}
+
+// buildServerTLSConfig constructs the tls.Config for the gRPC listener.
+// clientCAFile, when set, enables mTLS by requiring and verifying a client
+// certificate signed by that CA.
+func buildServerTLSConfig(ctx context.Context, certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	reloader, err := newCertReloader(ctx, certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		GetCertificate: reloader.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: reading client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("tls: no certificates found in %s", clientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return cfg, nil
+}
+
+// peerIdentityAllowlist rejects any RPC whose peer certificate CN/SAN is
+// not in allowed. It is only meaningful once mTLS (RequireAndVerifyClientCert)
+// is configured, since otherwise there may be no peer certificate at all.
+type peerIdentityAllowlist struct {
+	allowed map[string]struct{}
+}
+
+// newPeerIdentityAllowlist builds an allowlist from a comma-separated list
+// of CNs/SANs. An empty names string disables the allowlist (nil, false).
+func newPeerIdentityAllowlist(names string) (*peerIdentityAllowlist, bool) {
+	names = strings.TrimSpace(names)
+	if names == "" {
+		return nil, false
+	}
+	allowed := make(map[string]struct{})
+	for _, n := range strings.Split(names, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			allowed[n] = struct{}{}
+		}
+	}
+	return &peerIdentityAllowlist{allowed: allowed}, true
+}
+
+func (a *peerIdentityAllowlist) check(ctx context.Context) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "no peer info in context")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return status.Error(codes.Unauthenticated, "no peer certificate presented")
+	}
+
+	cert := tlsInfo.State.PeerCertificates[0]
+	identities := append([]string{cert.Subject.CommonName}, cert.DNSNames...)
+	for _, id := range identities {
+		if _, ok := a.allowed[id]; ok {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "peer identity %v not in allowlist", identities)
+}
+
+// UnaryServerInterceptor enforces the allowlist on unary RPCs.
+func (a *peerIdentityAllowlist) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.check(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor enforces the allowlist on streaming RPCs.
+func (a *peerIdentityAllowlist) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.check(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}